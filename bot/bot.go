@@ -4,13 +4,21 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net/http"
 	"os"
+	"sync/atomic"
+	"time"
 
 	"github.com/Noah-Huppert/kube-bot/chat"
 	"github.com/Noah-Huppert/kube-bot/config"
 	"github.com/Noah-Huppert/kube-bot/defs"
-	"github.com/nlopes/slack"
+	"github.com/Noah-Huppert/kube-bot/reconnect"
+	"github.com/Noah-Huppert/kube-bot/session"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/slack-go/slack"
+	"github.com/slack-go/slack/slackevents"
+	"github.com/slack-go/slack/socketmode"
 )
 
 // Bot acts as a chat bot based interface to the Kubernetes API. Leveraging the
@@ -37,24 +45,99 @@ type Bot struct {
 	// Slack
 	slackAPI *slack.Client
 
-	// slackRTM is the Slack API real time messaging client used to receive and
-	// respond to Slack API events
-	slackRTM *slack.RTM
+	// slackSocket is the Socket Mode client used to receive and respond to
+	// Slack API events over a websocket, replacing the deprecated RTM API
+	slackSocket *socketmode.Client
 
 	// registry holds all commands the bot can respond to
 	registry chat.Registry
 
+	// commands holds the typed, authorizable commands the bot can run, on
+	// top of the plain message registry
+	commands *chat.CommandRegistry
+
+	// dispatcher runs commands matched from incoming events and reports
+	// their outcome
+	dispatcher *chat.Dispatcher
+
 	// allParser is used to run a suite of Parsers on received messages
 	allParser *chat.AllParser
 
-	// ims holds the information about personal conversations (aka ims) that
-	// the bot has with users.
-	//
-	// Keys are im channel IDs. Values are
-	// TODO: Bot.ims
+	// normalizer cleans up Slack's escaped message syntax before a message
+	// is handed to allParser
+	normalizer *chat.Normalizer
+
+	// ims holds the state of in-progress personal conversations (aka ims)
+	// the bot is having with users, keyed by im channel ID. Backed by a
+	// pluggable session.Store so state can live in memory or in Redis
+	ims session.Store
+
+	// reconnectSup tracks Socket Mode reconnect attempts and computes the
+	// exponential backoff between them
+	reconnectSup *reconnect.Supervisor
+
+	// fatalErr receives an error that should stop Run() outright, e.g. an
+	// InvalidAuthEvent which no amount of reconnecting will fix
+	fatalErr chan error
+
+	// connected is 1 if the Socket Mode connection is currently up, 0
+	// otherwise. Read by the /healthz endpoint without touching Prometheus
+	connected int32
+
+	// giveUp is set to 1 once a fatal, non-retryable error (e.g. invalid
+	// credentials) has been sent on fatalErr, so runSocket knows to stop
+	// reconnecting even before Run() gets a chance to observe fatalErr and
+	// cancel ctx
+	giveUp int32
 
 	// chatEventCounter
 	chatEventCounter prometheus.Counter
+
+	// reconnectAttempts counts every reconnect attempt made against Slack
+	reconnectAttempts prometheus.Counter
+
+	// reconnectBackoff reports the backoff duration, in seconds, used for
+	// the most recent reconnect attempt
+	reconnectBackoff prometheus.Gauge
+
+	// connState reports whether the Socket Mode connection is currently up
+	// (1) or down (0)
+	connState prometheus.Gauge
+
+	// parseFailures counts messages that could not be parsed into a
+	// CmdRequest
+	parseFailures prometheus.Counter
+
+	// dispatchLatency tracks how long each command took to run, labeled by
+	// command name and whether it succeeded
+	dispatchLatency *prometheus.HistogramVec
+
+	// slackAPILatency tracks how long each Slack Web API call took, labeled
+	// by method name
+	slackAPILatency *prometheus.HistogramVec
+
+	// activeSessions reports how many personal IM conversations currently
+	// have pending state, e.g. an unanswered confirmation. Refreshed
+	// periodically from b.ims by reportActiveSessions rather than
+	// incremented/decremented at each call site, so it can't drift when a
+	// session expires unanswered
+	activeSessions prometheus.Gauge
+}
+
+// dispatchMetrics adapts Bot's Prometheus histogram to chat.DispatchMetrics
+// so Dispatcher does not need to depend on Prometheus directly.
+type dispatchMetrics struct {
+	hist *prometheus.HistogramVec
+}
+
+// ObserveDispatch implements chat.DispatchMetrics
+func (m dispatchMetrics) ObserveDispatch(cmd string, success bool, dur time.Duration) {
+	status := "error"
+	if success {
+		status = "success"
+	}
+
+	m.hist.WithLabelValues(cmd, status).Observe(dur.Seconds())
 }
 
 // NewBot creates a new Bot instance from the parameters specified in the
@@ -81,6 +164,20 @@ func NewBot(ctx context.Context, cfg config.Config) (*Bot, error) {
 		return nil, fmt.Errorf("error loading registry items: %s", err.Error())
 	}
 
+	// Commands
+	bot.commands = chat.NewCommandRegistry()
+	if err := bot.commands.Register(chat.NewHelpCommand(bot.commands)); err != nil {
+		return nil, fmt.Errorf("error registering help command: %s", err.Error())
+	}
+	bot.dispatcher = chat.NewDispatcher(bot.commands)
+
+	// Sessions
+	if cfg.Session.RedisAddr != "" {
+		bot.ims = session.NewRedisStore(cfg.Session.RedisAddr)
+	} else {
+		bot.ims = session.NewMemoryStore()
+	}
+
 	bot.chatEventCounter = prometheus.NewCounter(prometheus.CounterOpts{
 		Name: "chat_event_count",
 		Help: "Counts the number of chat events received",
@@ -89,112 +186,805 @@ func NewBot(ctx context.Context, cfg config.Config) (*Bot, error) {
 		return nil, fmt.Errorf("error registering chat event counter metric: %s", err.Error())
 	}
 
+	// Reconnect
+	bot.reconnectSup = reconnect.NewSupervisor(
+		bot.reconnectMin(), bot.reconnectMax(), bot.reconnectFactor(), cfg.Slack.Reconnect.Jitter)
+	bot.fatalErr = make(chan error, 1)
+
+	bot.reconnectAttempts = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "slack_reconnect_attempts_total",
+		Help: "Counts the number of times the bot has attempted to reconnect to Slack",
+	})
+	if err := prometheus.Register(bot.reconnectAttempts); err != nil {
+		return nil, fmt.Errorf("error registering reconnect attempts metric: %s", err.Error())
+	}
+
+	bot.reconnectBackoff = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "slack_reconnect_backoff_seconds",
+		Help: "The backoff duration used for the most recent Slack reconnect attempt",
+	})
+	if err := prometheus.Register(bot.reconnectBackoff); err != nil {
+		return nil, fmt.Errorf("error registering reconnect backoff metric: %s", err.Error())
+	}
+
+	bot.connState = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "slack_connection_state",
+		Help: "Whether the Socket Mode connection to Slack is up (1) or down (0)",
+	})
+	if err := prometheus.Register(bot.connState); err != nil {
+		return nil, fmt.Errorf("error registering connection state metric: %s", err.Error())
+	}
+
+	bot.parseFailures = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "chat_parse_failure_count",
+		Help: "Counts messages that could not be parsed into a command",
+	})
+	if err := prometheus.Register(bot.parseFailures); err != nil {
+		return nil, fmt.Errorf("error registering parse failure metric: %s", err.Error())
+	}
+
+	bot.dispatchLatency = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name: "command_dispatch_duration_seconds",
+		Help: "Tracks how long each command takes to run, labeled by command and outcome",
+	}, []string{"command", "status"})
+	if err := prometheus.Register(bot.dispatchLatency); err != nil {
+		return nil, fmt.Errorf("error registering dispatch latency metric: %s", err.Error())
+	}
+	bot.dispatcher.Metrics = dispatchMetrics{hist: bot.dispatchLatency}
+
+	bot.slackAPILatency = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name: "slack_api_call_duration_seconds",
+		Help: "Tracks how long each Slack Web API call takes, labeled by method",
+	}, []string{"method"})
+	if err := prometheus.Register(bot.slackAPILatency); err != nil {
+		return nil, fmt.Errorf("error registering Slack API latency metric: %s", err.Error())
+	}
+
+	bot.activeSessions = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "active_im_session_count",
+		Help: "Reports how many personal IM conversations currently have pending state",
+	})
+	if err := prometheus.Register(bot.activeSessions); err != nil {
+		return nil, fmt.Errorf("error registering active sessions metric: %s", err.Error())
+	}
+
 	// Make
 	return &bot, nil
 }
 
+// Defaults used to build the reconnect.Supervisor when the corresponding
+// Config.Slack.Reconnect field is left at its zero value.
+const (
+	defaultReconnectMin    = 1 * time.Second
+	defaultReconnectMax    = 2 * time.Minute
+	defaultReconnectFactor = 2.0
+)
+
+// reconnectMin returns the configured minimum reconnect backoff, or
+// defaultReconnectMin if none was configured.
+func (b *Bot) reconnectMin() time.Duration {
+	if b.Config.Slack.Reconnect.Min == 0 {
+		return defaultReconnectMin
+	}
+
+	return b.Config.Slack.Reconnect.Min
+}
+
+// reconnectMax returns the configured maximum reconnect backoff, or
+// defaultReconnectMax if none was configured.
+func (b *Bot) reconnectMax() time.Duration {
+	if b.Config.Slack.Reconnect.Max == 0 {
+		return defaultReconnectMax
+	}
+
+	return b.Config.Slack.Reconnect.Max
+}
+
+// reconnectFactor returns the configured reconnect backoff multiplier, or
+// defaultReconnectFactor if none was configured.
+func (b *Bot) reconnectFactor() float64 {
+	if b.Config.Slack.Reconnect.Factor == 0 {
+		return defaultReconnectFactor
+	}
+
+	return b.Config.Slack.Reconnect.Factor
+}
+
 // Run begins the process of receiving and responding to user messages. This
 // process will continue until Stop() is called.
 //
 // Returns the error that stopped execution. If Run() was stopped by a context
 // either the context.Canceled or context.DeadlineExceeded error will be
 // returned.
-func (b Bot) Run() error {
+func (b *Bot) Run() error {
 	b.logger.Println("running bot")
 
 	// Init Slack Lib
-	b.slackAPI = slack.New(b.Config.Slack.Token)
-	slack.SetLogger(b.slackLogger)
+	b.slackAPI = slack.New(b.Config.Slack.Token,
+		slack.OptionAppLevelToken(b.Config.Slack.AppToken),
+		slack.OptionLog(b.slackLogger))
+
+	// Metrics server
+	go b.runMetricsServer()
 
-	// Connect
-	b.slackRTM = b.slackAPI.NewRTM()
-	go b.slackRTM.ManageConnection()
+	// Drain executed command events so Dispatcher's goroutines never block
+	// sending to it
+	go b.logDispatchEvents()
 
-	// allParser
-	b.allParser = chat.NewAllParser(b.registry, b.slackAPI, b.slackRTM)
+	// Keep the active session gauge in sync with b.ims, including
+	// sessions that expire without ever being answered
+	go b.reportActiveSessions()
 
-	// Receive
-	go b.handleEvents(b.slackRTM.IncomingEvents)
+	// Connect, reconnecting with exponential backoff whenever the
+	// connection drops unintentionally
+	go b.runSocket()
 
 	select {
 	case <-b.ctx.Done():
 		b.logger.Printf("received shutdown request: %s", b.ctx.Err().Error())
 		return b.ctx.Err()
+	case err := <-b.fatalErr:
+		b.logger.Printf("fatal error, stopping: %s", err.Error())
+		b.ctxCancelFn()
+		return err
 	}
 
 	return nil
 }
 
-// handleEvents receives Slack events via the provided channel and processes
-// them accordingly. Returns the error that stopped execution.
-func (b Bot) handleEvents(in <-chan slack.RTMEvent) error {
-	b.logger.Println("starting to receive Slack events")
+// logDispatchEvents reads every ExecutedEvent off b.dispatcher.Events and
+// logs it. dispatcher.Dispatch sends on that channel after each command run,
+// so something must always be reading it or every dispatch goroutine past
+// the channel's buffer would block forever. Runs until b.ctx is done.
+func (b *Bot) logDispatchEvents() {
+	for {
+		select {
+		case <-b.ctx.Done():
+			return
+		case evt := <-b.dispatcher.Events:
+			if evt.Err != nil {
+				b.logger.Printf("command \"%s\" run by %s failed: %s\n", evt.Command, evt.UserID, evt.Err.Error())
+				continue
+			}
+
+			b.logger.Printf("command \"%s\" run by %s succeeded\n", evt.Command, evt.UserID)
+		}
+	}
+}
+
+// activeSessionsReportInterval is how often reportActiveSessions refreshes
+// the activeSessions gauge from b.ims.
+const activeSessionsReportInterval = 30 * time.Second
+
+// reportActiveSessions periodically sets activeSessions to b.ims's current
+// session count, rather than having callers Inc/Dec it themselves. This
+// keeps the gauge accurate even when a session is never answered and
+// instead just expires, which a manual Inc/Dec pairing would miss. Runs
+// until b.ctx is done.
+func (b *Bot) reportActiveSessions() {
+	ticker := time.NewTicker(activeSessionsReportInterval)
+	defer ticker.Stop()
 
 	for {
 		select {
 		case <-b.ctx.Done():
+			return
+		case <-ticker.C:
+			n, err := b.ims.Count()
+			if err != nil {
+				b.logger.Printf("error counting active sessions: %s\n", err.Error())
+				continue
+			}
+
+			b.activeSessions.Set(float64(n))
+		}
+	}
+}
+
+// runSocket opens a Socket Mode connection and hands its events to
+// handleEvents, looping to open a new connection whenever one drops
+// unintentionally. Each retry waits out the backoff reconnectSup computes,
+// so a string of drops backs off exponentially instead of reconnecting as
+// fast as Slack will allow. Stops once b.ctx is done or a fatal error has
+// set b.giveUp, whichever happens first.
+func (b *Bot) runSocket() {
+	for {
+		if b.ctx.Err() != nil || atomic.LoadInt32(&b.giveUp) == 1 {
+			return
+		}
+
+		b.slackSocket = socketmode.New(b.slackAPI, socketmode.OptionLog(b.slackLogger))
+		b.allParser = chat.NewAllParser(b.registry, b.slackAPI, b.slackSocket)
+		b.normalizer = chat.NewNormalizer(b.slackAPI)
+
+		attemptCtx, cancel := context.WithCancel(b.ctx)
+		go func() {
+			b.slackSocket.RunContext(attemptCtx)
+			cancel()
+		}()
+
+		// Blocks until this connection attempt ends, either because
+		// b.ctx was cancelled or because the connection dropped
+		b.handleEvents(b.slackSocket.Events, attemptCtx, cancel)
+		cancel()
+
+		if b.ctx.Err() != nil || atomic.LoadInt32(&b.giveUp) == 1 {
+			return
+		}
+
+		backoff := b.reconnectSup.NextBackoff()
+		b.reconnectAttempts.Inc()
+		b.reconnectBackoff.Set(backoff.Seconds())
+		b.logger.Printf("waiting %s before reconnecting to Slack\n", backoff)
+
+		select {
+		case <-b.ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+	}
+}
+
+// defaultMetricsBindAddr and defaultMetricsPath are used when the
+// corresponding Config.Metrics field is left at its zero value.
+// defaultHealthzPath is always used; Config.Metrics has no field for it.
+const (
+	defaultMetricsBindAddr = ":9090"
+	defaultMetricsPath     = "/metrics"
+	defaultHealthzPath     = "/healthz"
+)
+
+// runMetricsServer starts an HTTP server exposing Prometheus metrics and a
+// liveness endpoint reporting the Socket Mode connection state. Runs until
+// b.ctx is done.
+func (b *Bot) runMetricsServer() {
+	bindAddr := b.Config.Metrics.BindAddr
+	if bindAddr == "" {
+		bindAddr = defaultMetricsBindAddr
+	}
+
+	metricsPath := b.Config.Metrics.Path
+	if metricsPath == "" {
+		metricsPath = defaultMetricsPath
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(metricsPath, promhttp.Handler())
+	mux.HandleFunc(defaultHealthzPath, func(w http.ResponseWriter, r *http.Request) {
+		if atomic.LoadInt32(&b.connected) == 1 {
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte("ok"))
+			return
+		}
+
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte("disconnected from Slack"))
+	})
+
+	srv := &http.Server{
+		Addr:    bindAddr,
+		Handler: mux,
+	}
+
+	go func() {
+		<-b.ctx.Done()
+		srv.Close()
+	}()
+
+	b.logger.Printf("serving metrics on %s%s\n", bindAddr, metricsPath)
+	if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		b.logger.Printf("error serving metrics: %s\n", err.Error())
+	}
+}
+
+// handleEvents receives Slack Socket Mode events via the provided channel and
+// processes them accordingly, for as long as ctx is alive. ctx is the
+// connection attempt's own context, scoped to one runSocket iteration; cancel
+// stops that attempt's Socket Mode client. handleEvents returns once ctx is
+// done or the connection drops unintentionally, either way leaving it to
+// runSocket to decide whether and when to reconnect. Returns the error that
+// stopped execution.
+func (b *Bot) handleEvents(in <-chan socketmode.Event, ctx context.Context, cancel context.CancelFunc) error {
+	b.logger.Println("starting to receive Slack events")
+
+	for {
+		select {
+		case <-ctx.Done():
 			// Ctx has expired
-			return b.ctx.Err()
-		case msg := <-in:
+			return ctx.Err()
+		case evt := <-in:
 			// Received Slack API event
 			b.chatEventCounter.Inc()
-			switch event := msg.Data.(type) {
-			case *slack.MessageEvent:
-				if err := b.handleMessage(event); err != nil {
-					b.logger.Printf("error handling message: %s\n", err.Error())
+			switch evt.Type {
+			case socketmode.EventTypeEventsAPI:
+				apiEvt, ok := evt.Data.(slackevents.EventsAPIEvent)
+				if !ok {
+					break
+				}
+				b.slackSocket.Ack(*evt.Request)
+
+				switch innerEvt := apiEvt.InnerEvent.Data.(type) {
+				case *slackevents.MessageEvent:
+					if err := b.handleMessage(innerEvt); err != nil {
+						b.logger.Printf("error handling message: %s\n", err.Error())
+					}
+				case *slackevents.AppMentionEvent:
+					if err := b.handleMention(innerEvt); err != nil {
+						b.logger.Printf("error handling mention: %s\n", err.Error())
+					}
+				default:
+					if b.Config.Slack.LogUnhandledEvents {
+						b.logger.Printf("received unhandled inner event: %#v", innerEvt)
+					}
 				}
-			case *slack.InvalidAuthEvent:
-				b.logger.Println("invalid credentials")
-			case *slack.ConnectionErrorEvent:
-				b.logger.Printf("connection error: %s", event.Error())
-			case *slack.HelloEvent, *slack.ConnectingEvent, *slack.ConnectedEvent:
+			case socketmode.EventTypeConnectionError:
+				b.logger.Printf("connection error: %s", evt.Data)
+				b.noteUnintentionalDisconnect()
+				cancel()
+				return nil
+			case socketmode.EventTypeDisconnect:
+				if ctx.Err() != nil {
+					// Stop() already cancelled our context; this disconnect
+					// was intentional
+					continue
+				}
+				b.logger.Println("disconnected from Slack")
+				b.noteUnintentionalDisconnect()
+				cancel()
+				return nil
+			case socketmode.EventTypeConnected:
+				b.logger.Println("connected to Slack")
+				b.reconnectSup.Reset()
+				b.connState.Set(1)
+				atomic.StoreInt32(&b.connected, 1)
+			case socketmode.EventTypeInvalidAuth:
+				b.connState.Set(0)
+				atomic.StoreInt32(&b.connected, 0)
+				atomic.StoreInt32(&b.giveUp, 1)
+				// Send before cancelling ctx: Run()'s select reads both
+				// b.ctx.Done() and b.fatalErr, so cancelling first would
+				// make it a coin flip whether this error or
+				// context.Canceled is what Run() actually returns.
+				// fatalErr's own case in Run() cancels ctx for us once it
+				// wins that select; giveUp lets runSocket stop reconnecting
+				// in the meantime without racing that select itself.
+				b.fatalErr <- fmt.Errorf("invalid Slack credentials, giving up")
+				return nil
+			case socketmode.EventTypeHello, socketmode.EventTypeConnecting:
 				continue
 			default:
 				// If logging unhandled events
 				if b.Config.Slack.LogUnhandledEvents {
-					b.logger.Printf("received unhandled event: %s %#s", msg.Type, event)
+					b.logger.Printf("received unhandled event: %s %#v", evt.Type, evt.Data)
 				}
 			}
 		}
 	}
+}
 
-	return nil
+// noteUnintentionalDisconnect marks the connection as down. Reconnecting,
+// and the backoff before it, are runSocket's responsibility: Socket Mode
+// emits both a ConnectionError and a Disconnect event for a single drop, and
+// only the first of the two reaches here because handleEvents returns
+// immediately after, leaving the other to be read by nobody once this
+// connection attempt's events channel is abandoned.
+func (b *Bot) noteUnintentionalDisconnect() {
+	b.connState.Set(0)
+	atomic.StoreInt32(&b.connected, 0)
 }
 
-// handleMessage performs the appropriate actions for the provided message event.
-// Returns an error on failure, nil on success.
-func (b Bot) handleMessage(event *slack.MessageEvent) error {
-	msg := event.Msg
+// handleMessage performs the appropriate actions for the provided message
+// event. Returns an error on failure, nil on success.
+func (b *Bot) handleMessage(event *slackevents.MessageEvent) error {
+	// Ignore messages posted by bots, including our own replies: the
+	// Events API subscription echoes them straight back, and without this
+	// check a parse failure reply would trigger another parse failure
+	// reply forever
+	if event.BotID != "" || event.SubType == "bot_message" {
+		return nil
+	}
 
 	// Log
-	b.logger.Printf("received message: %s\n", msg.Text)
+	b.logger.Printf("received message: %s\n", event.Text)
+
+	return b.handleCmdText(event.Text, event.Channel, event.User, event.ThreadTimeStamp, event.TimeStamp)
+}
 
-	// Test augments
-	if cmdReq, err := b.allParser.Parse(msg); err == nil {
-		// Format message
-		str := "I'm still learning, here are your arguments:"
+// handleMention performs the appropriate actions for the provided app_mention
+// event, dispatching it through the same command pipeline as a direct
+// message.
+func (b *Bot) handleMention(event *slackevents.AppMentionEvent) error {
+	// Log
+	b.logger.Printf("received mention: %s\n", event.Text)
+
+	return b.handleCmdText(event.Text, event.Channel, event.User, event.ThreadTimeStamp, event.TimeStamp)
+}
 
-		for key, val := range cmdReq.Augments {
-			str += fmt.Sprintf("\n- %s=%s", key, val)
+// defaultSessionTTL is used when Config.Session.TTL is left at its zero
+// value.
+const defaultSessionTTL = 5 * time.Minute
+
+// handleCmdText consults any in-progress session.Session for channel before
+// falling back to fresh parsing. A pending confirmation or wizard step
+// consumes the message as its answer instead of being parsed as a new
+// command; otherwise text is parsed and, if found, dispatched through
+// b.dispatcher. Falls back to the legacy augment echoing behavior while most
+// commands are still being migrated onto the command framework.
+func (b *Bot) handleCmdText(text, channel, userID, threadTS, msgTS string) error {
+	respond := b.respondFn(channel, threadTS, msgTS)
+
+	if sess, ok, err := b.ims.Get(channel); err != nil {
+		b.logger.Printf("error reading session for %s: %s\n", channel, err.Error())
+	} else if ok {
+		return b.handleSessionReply(sess, text, userID, respond)
+	}
+
+	cleanText, mentions, err := b.normalizer.Normalize(text)
+	if err != nil {
+		b.logger.Printf("error normalizing message, parsing raw text instead: %s\n", err.Error())
+		cleanText = text
+	}
+
+	cmdReq, err := b.allParser.Parse(cleanText, channel, userID)
+	if err != nil {
+		b.parseFailures.Inc()
+		b.SendTxt(fmt.Sprintf("Whoops I had a brain fart: %s", err.Error()), channel)
+		return nil
+	}
+
+	cmdReq.RawText = text
+	cmdReq.Mentions = mentions
+
+	if cmd, ok := b.commands.Lookup(cmdReq.Cmd); ok {
+		if cmd.Auth != nil && !cmd.Auth(userID) {
+			b.SendTxt(fmt.Sprintf("Sorry, you're not authorized to run \"%s\".", cmd.Name), channel)
+			return nil
 		}
 
-		// Send
-		b.SendTxt(str, msg.Channel)
-	} else {
-		b.SendTxt(fmt.Sprintf("Whoops I had a brain fart: %s", err.Error()), msg.Channel)
+		if cmd.ConfirmRequired {
+			sess := &session.Session{
+				ChannelID: channel,
+				UserID:    userID,
+				Step:      session.StepConfirm,
+				Cmd:       cmd.Name,
+				Data:      cmdReq.Augments,
+				ExpiresAt: time.Now().Add(b.sessionTTL()),
+			}
+
+			if err := b.ims.Set(sess); err != nil {
+				return fmt.Errorf("error storing confirmation session: %s", err.Error())
+			}
+
+			b.SendTxt(fmt.Sprintf("Are you sure you want to run \"%s\"? (yes/no)", cmd.Name), channel)
+			return nil
+		}
+
+		if cmd.Wizard {
+			sess := &session.Session{
+				ChannelID: channel,
+				UserID:    userID,
+				Step:      session.StepWizard,
+				Cmd:       cmd.Name,
+				Data:      map[string]string{wizardStepKey: wizardSteps[0]},
+				ExpiresAt: time.Now().Add(b.sessionTTL()),
+			}
+
+			if err := b.ims.Set(sess); err != nil {
+				return fmt.Errorf("error storing wizard session: %s", err.Error())
+			}
+
+			b.SendTxt(fmt.Sprintf("Let's set up \"%s\". What %s?", cmd.Name, wizardSteps[0]), channel)
+			return nil
+		}
+	}
+
+	err = b.dispatcher.Dispatch(b.ctx, cmdReq, respond)
+	if err == nil {
+		return nil
+	}
+
+	if unauth, ok := err.(*chat.ErrUnauthorized); ok {
+		b.SendTxt(fmt.Sprintf("Sorry, you're not authorized to run \"%s\".", unauth.Name), channel)
+		return nil
 	}
 
+	if invalid, ok := err.(*chat.ErrInvalidParam); ok {
+		b.SendTxt(invalid.Error(), channel)
+		return nil
+	}
+
+	// Fall back to echoing parsed augments until every command has been
+	// ported onto the CommandRegistry
+	str := "I'm still learning, here are your arguments:"
+
+	for key, val := range cmdReq.Augments {
+		str += fmt.Sprintf("\n- %s=%s", key, val)
+	}
+
+	b.SendTxt(str, channel)
+
 	return nil
 }
 
-// SendTxt uses the slackRTM client to send a text message to the specified
+// handleSessionReply consumes text as the answer to sess's pending
+// confirmation or wizard step.
+func (b *Bot) handleSessionReply(sess *session.Session, text, userID string, respond func(chat.CmdResponse)) error {
+	switch sess.Step {
+	case session.StepConfirm:
+		if err := b.ims.Delete(sess.ChannelID); err != nil {
+			return fmt.Errorf("error clearing confirmation session: %s", err.Error())
+		}
+
+		if !chat.IsAffirmative(text) {
+			b.SendTxt("Okay, cancelled.", sess.ChannelID)
+			return nil
+		}
+
+		cmdReq := chat.CmdRequest{
+			Cmd:      sess.Cmd,
+			UserID:   userID,
+			Augments: sess.Data,
+		}
+
+		if err := b.dispatcher.Dispatch(b.ctx, cmdReq, respond); err != nil {
+			if unauth, ok := err.(*chat.ErrUnauthorized); ok {
+				b.SendTxt(fmt.Sprintf("Sorry, you're not authorized to run \"%s\".", unauth.Name), sess.ChannelID)
+				return nil
+			}
+
+			if invalid, ok := err.(*chat.ErrInvalidParam); ok {
+				b.SendTxt(invalid.Error(), sess.ChannelID)
+				return nil
+			}
+
+			return err
+		}
+
+		return nil
+	case session.StepWizard:
+		step, ok := sess.Data[wizardStepKey]
+		if !ok {
+			// Corrupt session; drop it rather than get stuck
+			if err := b.ims.Delete(sess.ChannelID); err != nil {
+				return fmt.Errorf("error clearing wizard session: %s", err.Error())
+			}
+
+			return nil
+		}
+
+		sess.Data[step] = text
+
+		if next, done := nextWizardStep(step); !done {
+			sess.Data[wizardStepKey] = next
+
+			if err := b.ims.Set(sess); err != nil {
+				return fmt.Errorf("error updating wizard session: %s", err.Error())
+			}
+
+			b.SendTxt(fmt.Sprintf("Got it. What %s?", next), sess.ChannelID)
+			return nil
+		}
+
+		if err := b.ims.Delete(sess.ChannelID); err != nil {
+			return fmt.Errorf("error clearing wizard session: %s", err.Error())
+		}
+
+		delete(sess.Data, wizardStepKey)
+
+		cmdReq := chat.CmdRequest{
+			Cmd:      sess.Cmd,
+			UserID:   userID,
+			Augments: sess.Data,
+		}
+
+		if err := b.dispatcher.Dispatch(b.ctx, cmdReq, respond); err != nil {
+			if unauth, ok := err.(*chat.ErrUnauthorized); ok {
+				b.SendTxt(fmt.Sprintf("Sorry, you're not authorized to run \"%s\".", unauth.Name), sess.ChannelID)
+				return nil
+			}
+
+			if invalid, ok := err.(*chat.ErrInvalidParam); ok {
+				b.SendTxt(invalid.Error(), sess.ChannelID)
+				return nil
+			}
+
+			return err
+		}
+
+		return nil
+	default:
+		// Unknown or unhandled step; drop the stale session rather than get
+		// stuck
+		if err := b.ims.Delete(sess.ChannelID); err != nil {
+			return fmt.Errorf("error clearing stale session: %s", err.Error())
+		}
+
+		return nil
+	}
+}
+
+// wizardSteps lists the fields a Wizard command's session collects, in the
+// order the bot asks the user for them: which namespace, which resource in
+// it, then what action to take.
+var wizardSteps = []string{"namespace", "resource", "action"}
+
+// wizardStepKey is the Session.Data key holding which wizardSteps entry a
+// wizard session is currently waiting on an answer for.
+const wizardStepKey = "_wizard_step"
+
+// nextWizardStep returns the wizardSteps entry after step, and false. If
+// step was the last entry it returns "", true.
+func nextWizardStep(step string) (string, bool) {
+	for i, s := range wizardSteps {
+		if s == step && i+1 < len(wizardSteps) {
+			return wizardSteps[i+1], false
+		}
+	}
+
+	return "", true
+}
+
+// sessionTTL returns the configured session lifetime, or defaultSessionTTL
+// if none was configured.
+func (b *Bot) sessionTTL() time.Duration {
+	if b.Config.Session.TTL == 0 {
+		return defaultSessionTTL
+	}
+
+	return b.Config.Session.TTL
+}
+
+// respondFn builds the callback Dispatch uses to deliver a Command's
+// CmdResponse, routing rich Attachments through SendAttachment and
+// everything else through plain text, threaded if either the response or the
+// triggering message asked for it. A reply to a message already in a thread
+// goes to that thread (threadTS); otherwise a response with Thread set starts
+// a thread off the triggering message itself (msgTS).
+func (b *Bot) respondFn(channel, threadTS, msgTS string) func(chat.CmdResponse) {
+	return func(resp chat.CmdResponse) {
+		thread := threadTS
+		if thread == "" && resp.Thread {
+			thread = msgTS
+		}
+
+		if resp.Attachment != nil {
+			b.SendAttachment(*resp.Attachment, channel, thread)
+			return
+		}
+
+		if thread != "" {
+			b.SendThreadTxt(resp.Text, channel, thread)
+			return
+		}
+
+		b.SendTxt(resp.Text, channel)
+	}
+}
+
+// SendTxt uses the Slack Web API client to send a text message to the
+// specified channel.
+func (b *Bot) SendTxt(txt string, channel string) {
+	defer b.timeSlackAPICall("PostMessage")()
+
+	if _, _, err := b.slackAPI.PostMessage(channel, slack.MsgOptionText(txt, false)); err != nil {
+		b.logger.Printf("error sending message: %s\n", err.Error())
+	}
+}
+
+// SendThreadTxt behaves like SendTxt but replies in the thread rooted at
+// threadTS instead of sending a new top level message.
+func (b *Bot) SendThreadTxt(txt string, channel string, threadTS string) {
+	defer b.timeSlackAPICall("PostMessage")()
+
+	if _, _, err := b.slackAPI.PostMessage(channel,
+		slack.MsgOptionText(txt, false),
+		slack.MsgOptionTS(threadTS)); err != nil {
+		b.logger.Printf("error sending threaded message: %s\n", err.Error())
+	}
+}
+
+// timeSlackAPICall starts a timer for a Slack Web API call named method.
+// Returns a func to be deferred, which records the elapsed time in
+// b.slackAPILatency.
+func (b *Bot) timeSlackAPICall(method string) func() {
+	start := time.Now()
+	return func() {
+		b.slackAPILatency.WithLabelValues(method).Observe(time.Since(start).Seconds())
+	}
+}
+
+// SendRich sends a colored, structured Slack attachment built from attach to
 // channel.
-func (b Bot) SendTxt(txt string, channel string) {
-	b.slackRTM.SendMessage(b.slackRTM.NewOutgoingMessage(txt, channel))
+func (b *Bot) SendRich(attach chat.Attachment, channel string) {
+	b.SendAttachment(attach, channel, "")
+}
+
+// SendAttachment behaves like SendRich, replying in the thread rooted at
+// threadTS if it is non-empty.
+func (b *Bot) SendAttachment(attach chat.Attachment, channel string, threadTS string) {
+	defer b.timeSlackAPICall("PostMessage")()
+
+	fields := make([]slack.AttachmentField, len(attach.Fields))
+	for i, f := range attach.Fields {
+		fields[i] = slack.AttachmentField{
+			Title: f.Title,
+			Value: f.Value,
+			Short: f.Short,
+		}
+	}
+
+	slackAttachment := slack.Attachment{
+		Color:      attach.Severity.Color(),
+		Title:      attach.Title,
+		TitleLink:  attach.TitleLink,
+		Text:       attach.Text,
+		Fields:     fields,
+		MarkdownIn: []string{"text", "fields"},
+		Blocks:     slack.Blocks{BlockSet: blocksFromAttachment(attach)},
+	}
+
+	opts := []slack.MsgOption{slack.MsgOptionAttachments(slackAttachment)}
+	if threadTS != "" {
+		opts = append(opts, slack.MsgOptionTS(threadTS))
+	}
+
+	if _, _, err := b.slackAPI.PostMessage(channel, opts...); err != nil {
+		b.logger.Printf("error sending attachment: %s\n", err.Error())
+	}
+}
+
+// blocksFromAttachment renders attach as Block Kit blocks: a section for
+// Title/Text, followed by one section per pair of Short Fields (non-Short
+// Fields each get their own section, matching how Slack lays them out when
+// rendered as legacy attachment fields). Kept separate from the
+// slack.Attachment fields above so attach still renders sensibly on clients
+// that only understand legacy attachments.
+func blocksFromAttachment(attach chat.Attachment) []slack.Block {
+	blocks := []slack.Block{}
+
+	if attach.Title != "" || attach.Text != "" {
+		text := attach.Text
+		if attach.Title != "" {
+			text = fmt.Sprintf("*%s*\n%s", attach.Title, attach.Text)
+		}
+
+		blocks = append(blocks, slack.NewSectionBlock(
+			slack.NewTextBlockObject(slack.MarkdownType, text, false, false),
+			nil, nil,
+		))
+	}
+
+	var shortRun []*slack.TextBlockObject
+	flushShortRun := func() {
+		if len(shortRun) == 0 {
+			return
+		}
+
+		blocks = append(blocks, slack.NewSectionBlock(nil, shortRun, nil))
+		shortRun = nil
+	}
+
+	for _, f := range attach.Fields {
+		obj := slack.NewTextBlockObject(slack.MarkdownType, fmt.Sprintf("*%s*\n%s", f.Title, f.Value), false, false)
+
+		if !f.Short {
+			flushShortRun()
+			blocks = append(blocks, slack.NewSectionBlock(obj, nil, nil))
+			continue
+		}
+
+		shortRun = append(shortRun, obj)
+		if len(shortRun) == 2 {
+			flushShortRun()
+		}
+	}
+	flushShortRun()
+
+	return blocks
 }
 
 // Stop ends the process of receiving and responding to user messages. This
 // will cause the Run() method to exit and return a context.Canceled error.
-func (b Bot) Stop() {
+func (b *Bot) Stop() {
 	b.ctxCancelFn()
 }
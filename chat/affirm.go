@@ -0,0 +1,22 @@
+package chat
+
+import "strings"
+
+// affirmativeWords are the replies treated as "yes" when confirming a
+// pending command.
+var affirmativeWords = map[string]bool{
+	"y":       true,
+	"yes":     true,
+	"yeah":    true,
+	"yep":     true,
+	"sure":    true,
+	"ok":      true,
+	"okay":    true,
+	"confirm": true,
+}
+
+// IsAffirmative reports whether text is a common affirmative reply, e.g.
+// "yes" or "y". Used to interpret a user's answer to a confirmation prompt.
+func IsAffirmative(text string) bool {
+	return affirmativeWords[strings.ToLower(strings.TrimSpace(text))]
+}
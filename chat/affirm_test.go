@@ -0,0 +1,26 @@
+package chat
+
+import "testing"
+
+func TestIsAffirmative(t *testing.T) {
+	cases := []struct {
+		text string
+		want bool
+	}{
+		{"yes", true},
+		{"Yes", true},
+		{"  y  ", true},
+		{"YEP", true},
+		{"confirm", true},
+		{"no", false},
+		{"nope", false},
+		{"", false},
+		{"maybe", false},
+	}
+
+	for _, c := range cases {
+		if got := IsAffirmative(c.text); got != c.want {
+			t.Errorf("IsAffirmative(%q) = %v, want %v", c.text, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package chat
+
+// Severity indicates how a response should be colored when rendered as a
+// Slack attachment, analogous to a log level.
+type Severity int
+
+const (
+	// SeverityInfo is the default, neutral severity
+	SeverityInfo Severity = iota
+
+	// SeverityGood indicates a healthy or successful result, e.g. a pod in
+	// the Running phase
+	SeverityGood
+
+	// SeverityWarn indicates a result worth a second look, but not
+	// necessarily broken
+	SeverityWarn
+
+	// SeverityError indicates something is wrong, e.g. a pod stuck in
+	// CrashLoopBackOff
+	SeverityError
+)
+
+// Color returns the hex color Slack should render an attachment with for
+// this Severity.
+func (s Severity) Color() string {
+	switch s {
+	case SeverityGood:
+		return "#2eb886"
+	case SeverityWarn:
+		return "#daa038"
+	case SeverityError:
+		return "#d00000"
+	default:
+		return "#439fe0"
+	}
+}
+
+// SeverityForPodPhase maps a Kubernetes pod phase to the Severity it should
+// be rendered with, e.g. in a pod list response.
+func SeverityForPodPhase(phase string) Severity {
+	switch phase {
+	case "Running", "Succeeded":
+		return SeverityGood
+	case "Pending":
+		return SeverityWarn
+	case "Failed", "CrashLoopBackOff", "Unknown":
+		return SeverityError
+	default:
+		return SeverityInfo
+	}
+}
+
+// Field is a single name/value pair shown in an attachment, e.g. a pod's
+// name or status.
+type Field struct {
+	// Title labels the field, e.g. "Namespace"
+	Title string
+
+	// Value is the field's content, e.g. "kube-system"
+	Value string
+
+	// Short hints that this Field can share a row with another Short Field
+	Short bool
+}
+
+// Attachment is a Slack-agnostic description of a colored, structured
+// response. Bot translates it into a slack.Attachment when sending.
+type Attachment struct {
+	// Severity determines the attachment's color
+	Severity Severity
+
+	// Title is the attachment's heading
+	Title string
+
+	// TitleLink, if set, makes Title clickable
+	TitleLink string
+
+	// Text is the attachment's markdown body
+	Text string
+
+	// Fields holds the attachment's tabular data, e.g. one Field per pod
+	// attribute
+	Fields []Field
+}
+
+// ResponseBuilder incrementally builds a rich CmdResponse, so command
+// handlers don't need to construct Slack API structures directly.
+type ResponseBuilder struct {
+	attachment Attachment
+}
+
+// NewResponseBuilder starts a ResponseBuilder at SeverityInfo.
+func NewResponseBuilder() *ResponseBuilder {
+	return &ResponseBuilder{}
+}
+
+// Severity sets the attachment's color.
+func (b *ResponseBuilder) Severity(s Severity) *ResponseBuilder {
+	b.attachment.Severity = s
+	return b
+}
+
+// Title sets the attachment's heading and, if link is non-empty, makes it
+// clickable.
+func (b *ResponseBuilder) Title(title, link string) *ResponseBuilder {
+	b.attachment.Title = title
+	b.attachment.TitleLink = link
+	return b
+}
+
+// Text sets the attachment's markdown body.
+func (b *ResponseBuilder) Text(text string) *ResponseBuilder {
+	b.attachment.Text = text
+	return b
+}
+
+// Field appends a Field to the attachment.
+func (b *ResponseBuilder) Field(title, value string, short bool) *ResponseBuilder {
+	b.attachment.Fields = append(b.attachment.Fields, Field{
+		Title: title,
+		Value: value,
+		Short: short,
+	})
+	return b
+}
+
+// Build returns the finished CmdResponse.
+func (b *ResponseBuilder) Build() CmdResponse {
+	attachment := b.attachment
+	return CmdResponse{
+		Text:       attachment.Text,
+		Attachment: &attachment,
+	}
+}
@@ -0,0 +1,188 @@
+package chat
+
+import (
+	"context"
+	"strconv"
+)
+
+// ParamType identifies the Go type a Param's value should be parsed into.
+type ParamType int
+
+const (
+	// ParamTypeString indicates a Param's value should be left as is
+	ParamTypeString ParamType = iota
+
+	// ParamTypeInt indicates a Param's value should be parsed as an int
+	ParamTypeInt
+
+	// ParamTypeFloat indicates a Param's value should be parsed as a float64
+	ParamTypeFloat
+
+	// ParamTypeBool indicates a Param's value should be parsed as a bool
+	ParamTypeBool
+)
+
+// Param describes a single named argument a Command accepts.
+type Param struct {
+	// Name is the argument's identifier, used both to look up its value in a
+	// CmdRequest and to render usage text
+	Name string
+
+	// Type is the Go type the argument's raw text should be parsed into
+	Type ParamType
+
+	// Description explains what the argument is used for. Shown in help text
+	Description string
+
+	// Required indicates the command cannot be dispatched unless this
+	// argument was provided
+	Required bool
+}
+
+// AuthFn decides whether the Slack user with the provided ID is allowed to
+// run a Command. Returns true if the user is authorized.
+type AuthFn func(userID string) bool
+
+// HandlerFn performs a Command's action. Returns the response to send back to
+// the user, or an error if the command failed.
+type HandlerFn func(ctx context.Context, req CmdRequest) (CmdResponse, error)
+
+// Command is a single user-invocable bot command, registered with a
+// CommandRegistry so it can be matched against incoming messages and listed
+// in the built-in help command.
+type Command struct {
+	// Name is the word used to invoke the command, e.g. "restart"
+	Name string
+
+	// Description explains what the command does. Shown in help text
+	Description string
+
+	// Examples holds example invocations shown alongside Description in help
+	// text
+	Examples []string
+
+	// Params lists the typed arguments the command accepts, in the order
+	// they must be provided
+	Params []Param
+
+	// Auth, if set, is consulted before Handler runs. A nil Auth allows any
+	// user to run the command
+	Auth AuthFn
+
+	// ConfirmRequired marks a command as destructive enough that the bot
+	// should ask "are you sure?" and wait for the user to confirm before
+	// Handler runs
+	ConfirmRequired bool
+
+	// Wizard marks a command as one the bot should walk the user through
+	// step by step, one question per message, rather than parsing all of
+	// its Params out of a single message. Mutually exclusive with
+	// ConfirmRequired: a Wizard command cannot also require confirmation
+	Wizard bool
+
+	// Handler performs the command's action
+	Handler HandlerFn
+}
+
+// ValidateAugments checks augments against c.Params: every Required Param
+// must be present, and every provided value must parse as its Param's
+// declared Type. Returns augments parsed into their declared Go types, keyed
+// by Param name, or an *ErrInvalidParam describing the first problem found.
+// A Param with no entry in augments and Required false is simply omitted
+// from the result.
+func (c *Command) ValidateAugments(augments map[string]string) (map[string]interface{}, error) {
+	typed := map[string]interface{}{}
+
+	for _, p := range c.Params {
+		raw, ok := augments[p.Name]
+		if !ok {
+			if p.Required {
+				return nil, &ErrInvalidParam{Command: c.Name, Param: p.Name, Reason: "required argument missing"}
+			}
+
+			continue
+		}
+
+		switch p.Type {
+		case ParamTypeInt:
+			v, err := strconv.Atoi(raw)
+			if err != nil {
+				return nil, &ErrInvalidParam{Command: c.Name, Param: p.Name, Reason: "must be an integer"}
+			}
+			typed[p.Name] = v
+		case ParamTypeFloat:
+			v, err := strconv.ParseFloat(raw, 64)
+			if err != nil {
+				return nil, &ErrInvalidParam{Command: c.Name, Param: p.Name, Reason: "must be a number"}
+			}
+			typed[p.Name] = v
+		case ParamTypeBool:
+			v, err := strconv.ParseBool(raw)
+			if err != nil {
+				return nil, &ErrInvalidParam{Command: c.Name, Param: p.Name, Reason: "must be true or false"}
+			}
+			typed[p.Name] = v
+		default:
+			typed[p.Name] = raw
+		}
+	}
+
+	return typed, nil
+}
+
+// CommandRegistry holds the set of Commands the bot knows how to run. It
+// extends the plain message Registry with typed parameters, authorization,
+// and help text generation.
+type CommandRegistry struct {
+	// commands maps a Command's Name to itself
+	commands map[string]*Command
+}
+
+// NewCommandRegistry creates an empty CommandRegistry.
+func NewCommandRegistry() *CommandRegistry {
+	return &CommandRegistry{
+		commands: map[string]*Command{},
+	}
+}
+
+// Register adds a Command so it can be dispatched. Returns an error if a
+// command with the same Name was already registered.
+func (r *CommandRegistry) Register(cmd *Command) error {
+	if _, ok := r.commands[cmd.Name]; ok {
+		return &ErrCommandExists{Name: cmd.Name}
+	}
+
+	r.commands[cmd.Name] = cmd
+
+	return nil
+}
+
+// Lookup finds the Command registered under name. The second return value is
+// false if no such Command exists.
+func (r *CommandRegistry) Lookup(name string) (*Command, bool) {
+	cmd, ok := r.commands[name]
+	return cmd, ok
+}
+
+// All returns every registered Command. The order is not guaranteed.
+func (r *CommandRegistry) All() []*Command {
+	cmds := make([]*Command, 0, len(r.commands))
+
+	for _, cmd := range r.commands {
+		cmds = append(cmds, cmd)
+	}
+
+	return cmds
+}
+
+// ErrCommandExists is returned by CommandRegistry.Register when a Command
+// with the same Name is already registered.
+type ErrCommandExists struct {
+	// Name is the conflicting command's name
+	Name string
+}
+
+// Error implements the error interface
+func (e *ErrCommandExists) Error() string {
+	return "command already registered: " + e.Name
+}
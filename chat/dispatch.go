@@ -0,0 +1,151 @@
+package chat
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// ExecutedEvent is emitted by a Dispatcher every time a Command finishes
+// running, whether it succeeded or failed.
+type ExecutedEvent struct {
+	// Command is the name of the Command that ran
+	Command string
+
+	// UserID is the Slack user who triggered the command
+	UserID string
+
+	// Err is the error the Command's Handler returned, if any
+	Err error
+}
+
+// Dispatcher matches incoming messages against a CommandRegistry and runs
+// the appropriate Command's Handler. Each dispatch runs in its own goroutine
+// so a slow or blocking command does not stall the rest of the bot.
+type Dispatcher struct {
+	// registry holds the Commands which can be dispatched
+	registry *CommandRegistry
+
+	// Events receives an ExecutedEvent after every Command run. Callers must
+	// read from this channel or dispatches will block once its buffer fills
+	Events chan ExecutedEvent
+
+	// Metrics, if set, is notified how long each Command's Handler took to
+	// run and whether it succeeded
+	Metrics DispatchMetrics
+}
+
+// DispatchMetrics receives instrumentation about each Command run so callers
+// can track dispatch latency without Dispatcher depending on a specific
+// metrics library.
+type DispatchMetrics interface {
+	// ObserveDispatch is called once a Command's Handler returns, with how
+	// long it took and whether it succeeded
+	ObserveDispatch(cmd string, success bool, dur time.Duration)
+}
+
+// ErrUnknownCommand is returned by Dispatch when no Command is registered
+// under the requested name.
+type ErrUnknownCommand struct {
+	// Name is the requested command name
+	Name string
+}
+
+// Error implements the error interface
+func (e *ErrUnknownCommand) Error() string {
+	return fmt.Sprintf("no command named \"%s\"", e.Name)
+}
+
+// ErrUnauthorized is returned by Dispatch when a Command's Auth callback
+// rejects the requesting user. Kept distinct from ErrUnknownCommand so
+// callers can tell "wrong command" apart from "not allowed to run this
+// command" and respond accordingly.
+type ErrUnauthorized struct {
+	// Name is the command the user was not authorized to run
+	Name string
+
+	// UserID is the user who was denied
+	UserID string
+}
+
+// Error implements the error interface
+func (e *ErrUnauthorized) Error() string {
+	return fmt.Sprintf("user %s is not authorized to run \"%s\"", e.UserID, e.Name)
+}
+
+// ErrInvalidParam is returned by Dispatch when a CmdRequest's Augments fail
+// validation against the Command's declared Params: a required Param is
+// missing, or a provided value doesn't parse as its declared Type.
+type ErrInvalidParam struct {
+	// Command is the command the argument was meant for
+	Command string
+
+	// Param is the name of the offending argument
+	Param string
+
+	// Reason explains what was wrong with it, e.g. "must be an integer"
+	Reason string
+}
+
+// Error implements the error interface
+func (e *ErrInvalidParam) Error() string {
+	return fmt.Sprintf("invalid argument \"%s\" for \"%s\": %s", e.Param, e.Command, e.Reason)
+}
+
+// NewDispatcher creates a Dispatcher which runs Commands from registry.
+func NewDispatcher(registry *CommandRegistry) *Dispatcher {
+	return &Dispatcher{
+		registry: registry,
+		Events:   make(chan ExecutedEvent, 10),
+	}
+}
+
+// Dispatch looks up the Command named by req.Cmd and runs its Handler in a
+// new goroutine, invoking respond with the Handler's CmdResponse once it
+// completes. Returns an *ErrUnknownCommand if no such Command is registered,
+// an *ErrUnauthorized if the requesting user fails the Command's Auth check,
+// or an *ErrInvalidParam if req.Augments fails validation against the
+// Command's Params; Handler errors are instead reported via the Events
+// channel.
+func (d *Dispatcher) Dispatch(ctx context.Context, req CmdRequest, respond func(CmdResponse)) error {
+	cmd, ok := d.registry.Lookup(req.Cmd)
+	if !ok {
+		return &ErrUnknownCommand{Name: req.Cmd}
+	}
+
+	if cmd.Auth != nil && !cmd.Auth(req.UserID) {
+		return &ErrUnauthorized{Name: cmd.Name, UserID: req.UserID}
+	}
+
+	typed, err := cmd.ValidateAugments(req.Augments)
+	if err != nil {
+		return err
+	}
+	req.Typed = typed
+
+	go func() {
+		start := time.Now()
+		resp, err := cmd.Handler(ctx, req)
+
+		if d.Metrics != nil {
+			d.Metrics.ObserveDispatch(cmd.Name, err == nil, time.Since(start))
+		}
+
+		d.Events <- ExecutedEvent{
+			Command: cmd.Name,
+			UserID:  req.UserID,
+			Err:     err,
+		}
+
+		if err != nil {
+			respond(CmdResponse{
+				Text: fmt.Sprintf("Error running \"%s\": %s", cmd.Name, err.Error()),
+			})
+			return
+		}
+
+		respond(resp)
+	}()
+
+	return nil
+}
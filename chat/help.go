@@ -0,0 +1,52 @@
+package chat
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// HelpCommandName is the name the built-in help Command is registered under.
+const HelpCommandName = "help"
+
+// NewHelpCommand builds the built-in help Command, which lists every Command
+// registered in reg, itself included, along with its description, params and
+// examples.
+func NewHelpCommand(reg *CommandRegistry) *Command {
+	return &Command{
+		Name:        HelpCommandName,
+		Description: "Lists the commands this bot understands",
+		Handler: func(ctx context.Context, req CmdRequest) (CmdResponse, error) {
+			return CmdResponse{
+				Text: renderHelp(reg),
+			}, nil
+		},
+	}
+}
+
+// renderHelp formats every Command registered in reg as help text, sorted by
+// name so output is stable.
+func renderHelp(reg *CommandRegistry) string {
+	cmds := reg.All()
+	sort.Slice(cmds, func(i, j int) bool {
+		return cmds[i].Name < cmds[j].Name
+	})
+
+	var b strings.Builder
+	b.WriteString("Here's what I can do:\n")
+
+	for _, cmd := range cmds {
+		fmt.Fprintf(&b, "\n*%s* - %s", cmd.Name, cmd.Description)
+
+		for _, p := range cmd.Params {
+			fmt.Fprintf(&b, "\n    `%s` - %s", p.Name, p.Description)
+		}
+
+		for _, ex := range cmd.Examples {
+			fmt.Fprintf(&b, "\n    e.g. `%s`", ex)
+		}
+	}
+
+	return b.String()
+}
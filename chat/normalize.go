@@ -0,0 +1,186 @@
+package chat
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"sync"
+
+	"github.com/slack-go/slack"
+)
+
+// mentionRe matches a Slack user mention, e.g. "<@U123>" or "<@U123|bob>"
+var mentionRe = regexp.MustCompile(`<@([UW][A-Z0-9]+)(?:\|[^>]*)?>`)
+
+// channelRe matches a Slack channel reference, e.g. "<#C123>" or
+// "<#C123|general>"
+var channelRe = regexp.MustCompile(`<#(C[A-Z0-9]+)(?:\|([^>]*))?>`)
+
+// linkRe matches a Slack formatted link, e.g. "<http://x|label>" or
+// "<http://x>"
+var linkRe = regexp.MustCompile(`<(https?://[^|>]+)(?:\|([^>]*))?>`)
+
+// emojiRe matches an emoji shortcode, e.g. ":thumbsup:"
+var emojiRe = regexp.MustCompile(`:([a-z0-9_+-]+):`)
+
+// userInfoGetter is the subset of slack.Client Normalizer needs, so tests can
+// fake it without a live Slack connection.
+type userInfoGetter interface {
+	GetUserInfo(userID string) (*slack.User, error)
+	GetConversationInfo(input *slack.GetConversationInfoInput) (*slack.Channel, error)
+}
+
+// Normalizer resolves Slack's escaped message syntax - user/channel
+// mentions, links, emoji shortcodes, and HTML entities - into plain text
+// before it is handed to a Parser. Resolved user and channel names are
+// cached since they rarely change within a conversation.
+type Normalizer struct {
+	// api is used to resolve user and channel IDs to names
+	api userInfoGetter
+
+	// mu guards userNames and channelNames
+	mu sync.Mutex
+
+	// userNames caches resolved display names by user ID
+	userNames map[string]string
+
+	// channelNames caches resolved channel names by channel ID
+	channelNames map[string]string
+}
+
+// NewNormalizer creates a Normalizer which resolves mentions via api.
+func NewNormalizer(api userInfoGetter) *Normalizer {
+	return &Normalizer{
+		api:          api,
+		userNames:    map[string]string{},
+		channelNames: map[string]string{},
+	}
+}
+
+// Normalize cleans text for parsing: mentions and channel references become
+// their human readable name, links become their label (or their bare URL if
+// unlabeled), emoji shortcodes become their unicode character, and HTML
+// entities are decoded. The returned map holds every resolved name, keyed
+// back to its original Slack ID, so handlers needing the ID can recover it.
+func (n *Normalizer) Normalize(text string) (string, map[string]string, error) {
+	mentions := map[string]string{}
+
+	text = mentionRe.ReplaceAllStringFunc(text, func(m string) string {
+		id := mentionRe.FindStringSubmatch(m)[1]
+
+		name, err := n.userName(id)
+		if err != nil {
+			// Leave the raw mention in place rather than fail the whole
+			// message over one unresolvable user
+			return m
+		}
+
+		mentions[name] = id
+		return "@" + name
+	})
+
+	text = channelRe.ReplaceAllStringFunc(text, func(m string) string {
+		groups := channelRe.FindStringSubmatch(m)
+		id, label := groups[1], groups[2]
+
+		name := label
+		if name == "" {
+			var err error
+			name, err = n.channelName(id)
+			if err != nil {
+				return m
+			}
+		}
+
+		mentions[name] = id
+		return "#" + name
+	})
+
+	text = linkRe.ReplaceAllStringFunc(text, func(m string) string {
+		groups := linkRe.FindStringSubmatch(m)
+		url, label := groups[1], groups[2]
+
+		if label != "" {
+			return label
+		}
+
+		return url
+	})
+
+	text = emojiRe.ReplaceAllStringFunc(text, expandEmoji)
+
+	text = html.UnescapeString(text)
+
+	return text, mentions, nil
+}
+
+// userName resolves userID to a display name, consulting the cache first.
+func (n *Normalizer) userName(userID string) (string, error) {
+	n.mu.Lock()
+	if name, ok := n.userNames[userID]; ok {
+		n.mu.Unlock()
+		return name, nil
+	}
+	n.mu.Unlock()
+
+	user, err := n.api.GetUserInfo(userID)
+	if err != nil {
+		return "", fmt.Errorf("error looking up user %s: %s", userID, err.Error())
+	}
+
+	n.mu.Lock()
+	n.userNames[userID] = user.Name
+	n.mu.Unlock()
+
+	return user.Name, nil
+}
+
+// channelName resolves channelID to its name, consulting the cache first.
+func (n *Normalizer) channelName(channelID string) (string, error) {
+	n.mu.Lock()
+	if name, ok := n.channelNames[channelID]; ok {
+		n.mu.Unlock()
+		return name, nil
+	}
+	n.mu.Unlock()
+
+	channel, err := n.api.GetConversationInfo(&slack.GetConversationInfoInput{
+		ChannelID: channelID,
+	})
+	if err != nil {
+		return "", fmt.Errorf("error looking up channel %s: %s", channelID, err.Error())
+	}
+
+	n.mu.Lock()
+	n.channelNames[channelID] = channel.Name
+	n.mu.Unlock()
+
+	return channel.Name, nil
+}
+
+// expandEmoji replaces an emoji shortcode match with its unicode character,
+// leaving unrecognized shortcodes untouched.
+func expandEmoji(match string) string {
+	name := emojiRe.FindStringSubmatch(match)[1]
+
+	if r, ok := emojiShortcodes[name]; ok {
+		return r
+	}
+
+	return match
+}
+
+// emojiShortcodes maps a subset of common Slack emoji shortcodes to their
+// unicode character. Unrecognized shortcodes are left as is rather than
+// failing normalization.
+var emojiShortcodes = map[string]string{
+	"thumbsup":         "\U0001F44D",
+	"thumbsdown":       "\U0001F44E",
+	"smile":            "\U0001F604",
+	"tada":             "\U0001F389",
+	"rocket":           "\U0001F680",
+	"white_check_mark": "✅",
+	"x":                "❌",
+	"warning":          "⚠️",
+	"eyes":             "\U0001F440",
+}
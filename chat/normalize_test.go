@@ -0,0 +1,165 @@
+package chat
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/slack-go/slack"
+)
+
+// fakeUserInfoGetter resolves the fixed set of users and channels in
+// users/channels, erroring on anything else.
+type fakeUserInfoGetter struct {
+	users    map[string]string
+	channels map[string]string
+}
+
+func (f *fakeUserInfoGetter) GetUserInfo(userID string) (*slack.User, error) {
+	name, ok := f.users[userID]
+	if !ok {
+		return nil, fmt.Errorf("no such user: %s", userID)
+	}
+
+	return &slack.User{Name: name}, nil
+}
+
+func (f *fakeUserInfoGetter) GetConversationInfo(input *slack.GetConversationInfoInput) (*slack.Channel, error) {
+	name, ok := f.channels[input.ChannelID]
+	if !ok {
+		return nil, fmt.Errorf("no such channel: %s", input.ChannelID)
+	}
+
+	channel := &slack.Channel{}
+	channel.Name = name
+	return channel, nil
+}
+
+func newTestNormalizer() *Normalizer {
+	return NewNormalizer(&fakeUserInfoGetter{
+		users:    map[string]string{"U123": "bob"},
+		channels: map[string]string{"C123": "general"},
+	})
+}
+
+func TestNormalizeMention(t *testing.T) {
+	n := newTestNormalizer()
+
+	text, mentions, err := n.Normalize("hey <@U123> check this out")
+	if err != nil {
+		t.Fatalf("Normalize() error: %s", err.Error())
+	}
+
+	if want := "hey @bob check this out"; text != want {
+		t.Errorf("text = %q, want %q", text, want)
+	}
+
+	if mentions["bob"] != "U123" {
+		t.Errorf("mentions[\"bob\"] = %q, want \"U123\"", mentions["bob"])
+	}
+}
+
+func TestNormalizeUnresolvableMentionLeftAsIs(t *testing.T) {
+	n := newTestNormalizer()
+
+	text, _, err := n.Normalize("hey <@U999> check this out")
+	if err != nil {
+		t.Fatalf("Normalize() error: %s", err.Error())
+	}
+
+	if want := "hey <@U999> check this out"; text != want {
+		t.Errorf("text = %q, want %q", text, want)
+	}
+}
+
+func TestNormalizeChannelWithLabel(t *testing.T) {
+	n := newTestNormalizer()
+
+	text, mentions, err := n.Normalize("see <#C456|random>")
+	if err != nil {
+		t.Fatalf("Normalize() error: %s", err.Error())
+	}
+
+	if want := "see #random"; text != want {
+		t.Errorf("text = %q, want %q", text, want)
+	}
+
+	if mentions["random"] != "C456" {
+		t.Errorf("mentions[\"random\"] = %q, want \"C456\"", mentions["random"])
+	}
+}
+
+func TestNormalizeChannelWithoutLabelResolvesName(t *testing.T) {
+	n := newTestNormalizer()
+
+	text, mentions, err := n.Normalize("see <#C123>")
+	if err != nil {
+		t.Fatalf("Normalize() error: %s", err.Error())
+	}
+
+	if want := "see #general"; text != want {
+		t.Errorf("text = %q, want %q", text, want)
+	}
+
+	if mentions["general"] != "C123" {
+		t.Errorf("mentions[\"general\"] = %q, want \"C123\"", mentions["general"])
+	}
+}
+
+func TestNormalizeLink(t *testing.T) {
+	n := newTestNormalizer()
+
+	labeled, _, err := n.Normalize("see <http://example.com|here>")
+	if err != nil {
+		t.Fatalf("Normalize() error: %s", err.Error())
+	}
+	if want := "see here"; labeled != want {
+		t.Errorf("text = %q, want %q", labeled, want)
+	}
+
+	unlabeled, _, err := n.Normalize("see <http://example.com>")
+	if err != nil {
+		t.Fatalf("Normalize() error: %s", err.Error())
+	}
+	if want := "see http://example.com"; unlabeled != want {
+		t.Errorf("text = %q, want %q", unlabeled, want)
+	}
+}
+
+func TestNormalizeEmojiShortcode(t *testing.T) {
+	n := newTestNormalizer()
+
+	text, _, err := n.Normalize("nice work :thumbsup:")
+	if err != nil {
+		t.Fatalf("Normalize() error: %s", err.Error())
+	}
+
+	if want := "nice work \U0001F44D"; text != want {
+		t.Errorf("text = %q, want %q", text, want)
+	}
+}
+
+func TestNormalizeUnknownEmojiShortcodeLeftAsIs(t *testing.T) {
+	n := newTestNormalizer()
+
+	text, _, err := n.Normalize("nice work :not_a_real_emoji:")
+	if err != nil {
+		t.Fatalf("Normalize() error: %s", err.Error())
+	}
+
+	if want := "nice work :not_a_real_emoji:"; text != want {
+		t.Errorf("text = %q, want %q", text, want)
+	}
+}
+
+func TestNormalizeHTMLEntities(t *testing.T) {
+	n := newTestNormalizer()
+
+	text, _, err := n.Normalize("a &amp; b &lt; c")
+	if err != nil {
+		t.Fatalf("Normalize() error: %s", err.Error())
+	}
+
+	if want := "a & b < c"; text != want {
+		t.Errorf("text = %q, want %q", text, want)
+	}
+}
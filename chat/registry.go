@@ -0,0 +1,134 @@
+package chat
+
+import (
+	"fmt"
+
+	"github.com/slack-go/slack"
+	"github.com/slack-go/slack/socketmode"
+)
+
+// Parser recognizes one command's plain text syntax and turns a matching
+// message into a CmdRequest. Registered with a Registry so AllParser can try
+// every known syntax against an incoming message.
+type Parser interface {
+	// Name identifies the command this Parser recognizes, e.g. for log
+	// messages
+	Name() string
+
+	// Parse attempts to read a CmdRequest for channel/userID out of text.
+	// Returns an *ErrNoMatch if text isn't this Parser's command
+	Parse(text, channel, userID string) (CmdRequest, error)
+}
+
+// Registry holds the set of Parsers AllParser tries against an incoming
+// message. Kept as an interface, rather than a concrete type, so it can be
+// swapped for a test double or an alternate source of Parsers.
+type Registry interface {
+	// Register adds p so AllParser will try it against future messages.
+	// Returns an error if a Parser is already registered under the same
+	// Name
+	Register(p Parser) error
+
+	// All returns every registered Parser. The order is not guaranteed
+	All() []Parser
+}
+
+// ErrParserExists is returned by Registry.Register when a Parser with the
+// same Name is already registered.
+type ErrParserExists struct {
+	// Name is the conflicting Parser's name
+	Name string
+}
+
+// Error implements the error interface
+func (e *ErrParserExists) Error() string {
+	return fmt.Sprintf("parser already registered: %s", e.Name)
+}
+
+// defaultRegistry is the Registry implementation NewDefaultRegistry returns.
+type defaultRegistry struct {
+	// parsers maps a Parser's Name to itself
+	parsers map[string]Parser
+}
+
+// NewDefaultRegistry creates an empty Registry backed by an in-memory map.
+func NewDefaultRegistry() Registry {
+	return &defaultRegistry{
+		parsers: map[string]Parser{},
+	}
+}
+
+// Register implements Registry.Register
+func (r *defaultRegistry) Register(p Parser) error {
+	if _, ok := r.parsers[p.Name()]; ok {
+		return &ErrParserExists{Name: p.Name()}
+	}
+
+	r.parsers[p.Name()] = p
+
+	return nil
+}
+
+// All implements Registry.All
+func (r *defaultRegistry) All() []Parser {
+	parsers := make([]Parser, 0, len(r.parsers))
+
+	for _, p := range r.parsers {
+		parsers = append(parsers, p)
+	}
+
+	return parsers
+}
+
+// ErrNoMatch is returned by AllParser.Parse when no registered Parser
+// recognizes a message's text.
+type ErrNoMatch struct {
+	// Text is the message text nothing matched
+	Text string
+}
+
+// Error implements the error interface
+func (e *ErrNoMatch) Error() string {
+	return fmt.Sprintf("no command recognized in %q", e.Text)
+}
+
+// AllParser tries every Parser in a Registry against an incoming message,
+// returning the first match. It also holds the Slack Web API and Socket Mode
+// clients it was created with; Parse does not pass them on, so a Parser sees
+// only the message text, channel and user ID.
+type AllParser struct {
+	// registry holds the Parsers to try
+	registry Registry
+
+	// api is the Slack Web API client AllParser was created with
+	api *slack.Client
+
+	// socket is the Socket Mode client AllParser was created with
+	socket *socketmode.Client
+}
+
+// NewAllParser creates an AllParser which tries every Parser in registry and
+// holds api and socket.
+func NewAllParser(registry Registry, api *slack.Client, socket *socketmode.Client) *AllParser {
+	return &AllParser{
+		registry: registry,
+		api:      api,
+		socket:   socket,
+	}
+}
+
+// Parse tries every Parser registered with p.registry against text in turn,
+// returning the first successful match. Returns an *ErrNoMatch if none
+// matches.
+func (p *AllParser) Parse(text, channel, userID string) (CmdRequest, error) {
+	for _, parser := range p.registry.All() {
+		req, err := parser.Parse(text, channel, userID)
+		if err != nil {
+			continue
+		}
+
+		return req, nil
+	}
+
+	return CmdRequest{}, &ErrNoMatch{Text: text}
+}
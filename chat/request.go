@@ -0,0 +1,28 @@
+package chat
+
+// CmdRequest is a single parsed invocation of a Command, ready to be
+// dispatched.
+type CmdRequest struct {
+	// Cmd is the name of the Command to run
+	Cmd string
+
+	// UserID is the Slack user who sent the message
+	UserID string
+
+	// Augments holds the command's arguments, keyed by Param name
+	Augments map[string]string
+
+	// RawText is the message text exactly as Slack sent it, before
+	// Normalizer cleaned it up for parsing
+	RawText string
+
+	// Mentions maps each user/channel name Normalizer resolved while
+	// cleaning RawText back to its original Slack ID, so handlers that need
+	// the ID (e.g. to @-mention the user back) don't have to re-resolve it
+	Mentions map[string]string
+
+	// Typed holds Augments parsed into their Command Param-declared Go
+	// types, keyed by Param name. Populated by Dispatcher.Dispatch just
+	// before a Command's Handler runs; empty beforehand
+	Typed map[string]interface{}
+}
@@ -0,0 +1,16 @@
+package chat
+
+// CmdResponse is the result a Command's HandlerFn produces. The dispatcher
+// turns it into one or more Slack messages.
+type CmdResponse struct {
+	// Text is sent as the message body. Ignored if Attachment is set
+	Text string
+
+	// Thread, if true, replies in a thread off the triggering message
+	// instead of sending a new top level message
+	Thread bool
+
+	// Attachment, if set, is rendered as a colored Slack attachment instead
+	// of a plain text message
+	Attachment *Attachment
+}
@@ -0,0 +1,83 @@
+// Package config defines the settings Bot needs to run, typically loaded
+// from the environment by main and passed into bot.NewBot.
+package config
+
+import "time"
+
+// Config holds every setting Bot needs to run.
+type Config struct {
+	// Slack holds the credentials and behavior tweaks for talking to Slack
+	Slack SlackConfig
+
+	// Session holds the settings for personal IM conversation state
+	Session SessionConfig
+
+	// Metrics holds the settings for the Prometheus metrics HTTP endpoint
+	Metrics MetricsConfig
+}
+
+// MetricsConfig configures the HTTP server Bot exposes Prometheus metrics
+// and a liveness endpoint on. Any field left at its zero value falls back
+// to Bot's own default.
+type MetricsConfig struct {
+	// BindAddr is the address the metrics HTTP server listens on, e.g.
+	// ":9090"
+	BindAddr string
+
+	// Path is the URL path Prometheus metrics are served under
+	Path string
+}
+
+// SessionConfig holds the settings for Bot's personal IM conversation state,
+// backed by a session.Store.
+type SessionConfig struct {
+	// RedisAddr, if set, backs session state with a Redis instance at this
+	// address instead of the default in-memory store, so state survives a
+	// restart and can be shared across bot replicas
+	RedisAddr string
+
+	// TTL is how long a session may sit unanswered before it is considered
+	// stale and dropped. Left at its zero value, Bot falls back to its own
+	// default
+	TTL time.Duration
+}
+
+// SlackConfig holds the credentials and behavior tweaks Bot uses to connect
+// to Slack.
+type SlackConfig struct {
+	// Token is the bot user OAuth token, used to authenticate Slack Web API
+	// calls
+	Token string
+
+	// AppToken is the app level token, used to authenticate the Socket Mode
+	// websocket connection
+	AppToken string
+
+	// LogUnhandledEvents, if true, logs the full contents of any Slack
+	// event Bot does not otherwise act on. Useful when diagnosing missing
+	// functionality, noisy in normal operation
+	LogUnhandledEvents bool
+
+	// Reconnect configures the backoff Bot uses between Socket Mode
+	// reconnect attempts
+	Reconnect ReconnectConfig
+}
+
+// ReconnectConfig configures the exponential backoff reconnect.Supervisor
+// uses between Socket Mode reconnect attempts. Any field left at its zero
+// value falls back to Bot's own default.
+type ReconnectConfig struct {
+	// Min is the backoff duration used for the first reconnect attempt
+	Min time.Duration
+
+	// Max caps the computed backoff duration
+	Max time.Duration
+
+	// Factor is multiplied into Min once per attempt, e.g. 2 doubles the
+	// backoff each time
+	Factor float64
+
+	// Jitter, if true, randomizes each computed backoff instead of using
+	// it as is
+	Jitter bool
+}
@@ -0,0 +1,23 @@
+// Package defs holds the built-in command definitions Bot loads into its
+// chat.Registry on startup. It is empty for now: every command so far has
+// been ported straight onto the typed chat.CommandRegistry instead, so
+// AllLoader has nothing to register yet.
+package defs
+
+import "github.com/Noah-Huppert/kube-bot/chat"
+
+// AllLoader registers every built-in chat.Parser with a chat.Registry. New
+// built-in commands are added here as the bot's plain-text command set
+// grows.
+type AllLoader struct{}
+
+// NewAllLoader creates an AllLoader.
+func NewAllLoader() *AllLoader {
+	return &AllLoader{}
+}
+
+// Load registers every built-in Parser with registry. Returns an error if
+// any Parser fails to register.
+func (l *AllLoader) Load(registry chat.Registry) error {
+	return nil
+}
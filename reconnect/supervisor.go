@@ -0,0 +1,79 @@
+package reconnect
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+// Supervisor tracks reconnect attempts against a Slack connection and
+// computes the exponential backoff duration to wait before the next retry.
+// It does not perform the reconnect itself; callers drive it from their own
+// connection event loop and call NextBackoff/Reset as connections drop and
+// come back.
+type Supervisor struct {
+	// Min is the backoff duration used for the first retry, and the floor
+	// every later backoff is randomized above when Jitter is enabled
+	Min time.Duration
+
+	// Max caps the computed backoff duration
+	Max time.Duration
+
+	// Factor is multiplied into Min once per attempt, e.g. 2 doubles the
+	// backoff each time
+	Factor float64
+
+	// Jitter, if true, randomizes each computed backoff to a value in
+	// [Min, dur) instead of returning dur itself
+	Jitter bool
+
+	// attempts counts how many times NextBackoff has been called since the
+	// last Reset
+	attempts int
+}
+
+// NewSupervisor creates a Supervisor with the given backoff parameters.
+func NewSupervisor(min, max time.Duration, factor float64, jitter bool) *Supervisor {
+	return &Supervisor{
+		Min:    min,
+		Max:    max,
+		Factor: factor,
+		Jitter: jitter,
+	}
+}
+
+// NextBackoff records another reconnect attempt and returns how long to wait
+// before retrying: dur = Min * Factor^attempts, randomized into [Min, dur)
+// when Jitter is set, capped at Max.
+func (s *Supervisor) NextBackoff() time.Duration {
+	// Cap in float64 before converting to a Duration. Min * Factor^attempts
+	// grows without bound as attempts climbs during a long outage, and once
+	// it exceeds what an int64 nanosecond count can hold, converting it to
+	// a Duration first and comparing against Max after overflows/wraps
+	// negative instead of capping.
+	durFloat := float64(s.Min) * math.Pow(s.Factor, float64(s.attempts))
+	s.attempts++
+
+	if durFloat > float64(s.Max) {
+		durFloat = float64(s.Max)
+	}
+
+	dur := time.Duration(durFloat)
+
+	if s.Jitter && dur > s.Min {
+		dur = s.Min + time.Duration(rand.Int63n(int64(dur-s.Min)))
+	}
+
+	return dur
+}
+
+// Reset clears the attempt count, e.g. after a successful reconnect. The
+// next NextBackoff call will return Min again.
+func (s *Supervisor) Reset() {
+	s.attempts = 0
+}
+
+// Attempts returns the number of reconnect attempts since the last Reset.
+func (s *Supervisor) Attempts() int {
+	return s.attempts
+}
@@ -0,0 +1,67 @@
+package reconnect
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNextBackoffDoublesUpToMax(t *testing.T) {
+	s := NewSupervisor(1*time.Second, 10*time.Second, 2.0, false)
+
+	want := []time.Duration{1 * time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 10 * time.Second}
+
+	for i, w := range want {
+		if got := s.NextBackoff(); got != w {
+			t.Errorf("attempt %d: got %s, want %s", i, got, w)
+		}
+	}
+}
+
+func TestNextBackoffJitterStaysInRange(t *testing.T) {
+	s := NewSupervisor(1*time.Second, 10*time.Second, 2.0, true)
+
+	for i := 0; i < 20; i++ {
+		got := s.NextBackoff()
+		if got < 1*time.Second || got > 10*time.Second {
+			t.Fatalf("attempt %d: backoff %s out of range [1s, 10s]", i, got)
+		}
+	}
+}
+
+func TestReset(t *testing.T) {
+	s := NewSupervisor(1*time.Second, 10*time.Second, 2.0, false)
+
+	s.NextBackoff()
+	s.NextBackoff()
+
+	if s.Attempts() != 2 {
+		t.Fatalf("Attempts() = %d, want 2", s.Attempts())
+	}
+
+	s.Reset()
+
+	if s.Attempts() != 0 {
+		t.Fatalf("Attempts() after Reset() = %d, want 0", s.Attempts())
+	}
+
+	if got := s.NextBackoff(); got != 1*time.Second {
+		t.Fatalf("NextBackoff() after Reset() = %s, want 1s", got)
+	}
+}
+
+// TestNextBackoffStaysCappedDuringLongOutage guards against Min *
+// Factor^attempts overflowing once attempts climbs high enough that the
+// float64 result no longer fits in an int64 nanosecond count.
+func TestNextBackoffStaysCappedDuringLongOutage(t *testing.T) {
+	s := NewSupervisor(1*time.Second, 2*time.Minute, 2.0, false)
+
+	for i := 0; i < 100; i++ {
+		got := s.NextBackoff()
+		if got < 0 {
+			t.Fatalf("attempt %d: backoff went negative: %s", i, got)
+		}
+		if got > 2*time.Minute {
+			t.Fatalf("attempt %d: backoff %s exceeds Max 2m", i, got)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package session
+
+import "sync"
+
+// MemoryStore is a Store backed by an in-process map. State is lost on
+// restart and not shared between bot replicas, which is fine for single
+// instance deployments.
+type MemoryStore struct {
+	// mu guards sessions
+	mu sync.Mutex
+
+	// sessions maps a channel ID to its Session
+	sessions map[string]*Session
+}
+
+// NewMemoryStore creates an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		sessions: map[string]*Session{},
+	}
+}
+
+// Get implements Store.Get
+func (s *MemoryStore) Get(channelID string) (*Session, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sess, ok := s.sessions[channelID]
+	if !ok {
+		return nil, false, nil
+	}
+
+	if sess.Expired() {
+		delete(s.sessions, channelID)
+		return nil, false, nil
+	}
+
+	return sess, true, nil
+}
+
+// Set implements Store.Set
+func (s *MemoryStore) Set(sess *Session) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sessions[sess.ChannelID] = sess
+
+	return nil
+}
+
+// Delete implements Store.Delete
+func (s *MemoryStore) Delete(channelID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.sessions, channelID)
+
+	return nil
+}
+
+// Count implements Store.Count. Expired sessions are purged as they are
+// found rather than counted, so a channel whose session expired without
+// ever being Get also stops contributing to the count.
+func (s *MemoryStore) Count() (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for channelID, sess := range s.sessions {
+		if sess.Expired() {
+			delete(s.sessions, channelID)
+		}
+	}
+
+	return len(s.sessions), nil
+}
@@ -0,0 +1,115 @@
+package session
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMemoryStoreSetGet(t *testing.T) {
+	s := NewMemoryStore()
+
+	sess := &Session{
+		ChannelID: "C1",
+		UserID:    "U1",
+		Step:      StepConfirm,
+		Cmd:       "restart",
+		ExpiresAt: time.Now().Add(time.Minute),
+	}
+
+	if err := s.Set(sess); err != nil {
+		t.Fatalf("Set() error: %s", err.Error())
+	}
+
+	got, ok, err := s.Get("C1")
+	if err != nil {
+		t.Fatalf("Get() error: %s", err.Error())
+	}
+	if !ok {
+		t.Fatal("Get() ok = false, want true")
+	}
+	if got.Cmd != "restart" {
+		t.Errorf("got.Cmd = %q, want \"restart\"", got.Cmd)
+	}
+}
+
+func TestMemoryStoreGetMissing(t *testing.T) {
+	s := NewMemoryStore()
+
+	_, ok, err := s.Get("nope")
+	if err != nil {
+		t.Fatalf("Get() error: %s", err.Error())
+	}
+	if ok {
+		t.Fatal("Get() ok = true, want false")
+	}
+}
+
+func TestMemoryStoreGetExpired(t *testing.T) {
+	s := NewMemoryStore()
+
+	sess := &Session{
+		ChannelID: "C1",
+		ExpiresAt: time.Now().Add(-time.Minute),
+	}
+
+	if err := s.Set(sess); err != nil {
+		t.Fatalf("Set() error: %s", err.Error())
+	}
+
+	_, ok, err := s.Get("C1")
+	if err != nil {
+		t.Fatalf("Get() error: %s", err.Error())
+	}
+	if ok {
+		t.Fatal("Get() ok = true for expired session, want false")
+	}
+}
+
+func TestMemoryStoreDelete(t *testing.T) {
+	s := NewMemoryStore()
+
+	sess := &Session{ChannelID: "C1", ExpiresAt: time.Now().Add(time.Minute)}
+	if err := s.Set(sess); err != nil {
+		t.Fatalf("Set() error: %s", err.Error())
+	}
+
+	if err := s.Delete("C1"); err != nil {
+		t.Fatalf("Delete() error: %s", err.Error())
+	}
+
+	_, ok, err := s.Get("C1")
+	if err != nil {
+		t.Fatalf("Get() error: %s", err.Error())
+	}
+	if ok {
+		t.Fatal("Get() ok = true after Delete(), want false")
+	}
+}
+
+func TestMemoryStoreDeleteMissingIsNotAnError(t *testing.T) {
+	s := NewMemoryStore()
+
+	if err := s.Delete("nope"); err != nil {
+		t.Fatalf("Delete() error: %s", err.Error())
+	}
+}
+
+func TestMemoryStoreCount(t *testing.T) {
+	s := NewMemoryStore()
+
+	if n, err := s.Count(); err != nil || n != 0 {
+		t.Fatalf("Count() = %d, %v, want 0, nil", n, err)
+	}
+
+	s.Set(&Session{ChannelID: "C1", ExpiresAt: time.Now().Add(time.Minute)})
+	s.Set(&Session{ChannelID: "C2", ExpiresAt: time.Now().Add(time.Minute)})
+	s.Set(&Session{ChannelID: "C3", ExpiresAt: time.Now().Add(-time.Minute)})
+
+	n, err := s.Count()
+	if err != nil {
+		t.Fatalf("Count() error: %s", err.Error())
+	}
+	if n != 2 {
+		t.Fatalf("Count() = %d, want 2 (expired session should not count)", n)
+	}
+}
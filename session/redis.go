@@ -0,0 +1,88 @@
+package session
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// redisKeyPrefix namespaces Session keys within a shared Redis instance.
+const redisKeyPrefix = "kube-bot:session:"
+
+// RedisStore is a Store backed by Redis, allowing Session state to be shared
+// across multiple bot replicas. Sessions are stored as JSON with Redis'
+// native key TTL enforcing expiry.
+type RedisStore struct {
+	// client is the Redis client used to read and write Sessions
+	client *redis.Client
+}
+
+// NewRedisStore creates a RedisStore which talks to the Redis instance at
+// addr.
+func NewRedisStore(addr string) *RedisStore {
+	return &RedisStore{
+		client: redis.NewClient(&redis.Options{
+			Addr: addr,
+		}),
+	}
+}
+
+// Get implements Store.Get
+func (s *RedisStore) Get(channelID string) (*Session, bool, error) {
+	raw, err := s.client.Get(context.Background(), redisKeyPrefix+channelID).Bytes()
+	if err == redis.Nil {
+		return nil, false, nil
+	} else if err != nil {
+		return nil, false, fmt.Errorf("error reading session from redis: %s", err.Error())
+	}
+
+	var sess Session
+	if err := json.Unmarshal(raw, &sess); err != nil {
+		return nil, false, fmt.Errorf("error decoding session: %s", err.Error())
+	}
+
+	if sess.Expired() {
+		return nil, false, nil
+	}
+
+	return &sess, true, nil
+}
+
+// Set implements Store.Set
+func (s *RedisStore) Set(sess *Session) error {
+	raw, err := json.Marshal(sess)
+	if err != nil {
+		return fmt.Errorf("error encoding session: %s", err.Error())
+	}
+
+	ttl := time.Until(sess.ExpiresAt)
+
+	if err := s.client.Set(context.Background(), redisKeyPrefix+sess.ChannelID, raw, ttl).Err(); err != nil {
+		return fmt.Errorf("error writing session to redis: %s", err.Error())
+	}
+
+	return nil
+}
+
+// Delete implements Store.Delete
+func (s *RedisStore) Delete(channelID string) error {
+	if err := s.client.Del(context.Background(), redisKeyPrefix+channelID).Err(); err != nil {
+		return fmt.Errorf("error deleting session from redis: %s", err.Error())
+	}
+
+	return nil
+}
+
+// Count implements Store.Count. Relies on Redis' own key TTL to have already
+// expired stale sessions, so every key under redisKeyPrefix counts.
+func (s *RedisStore) Count() (int, error) {
+	keys, err := s.client.Keys(context.Background(), redisKeyPrefix+"*").Result()
+	if err != nil {
+		return 0, fmt.Errorf("error listing sessions in redis: %s", err.Error())
+	}
+
+	return len(keys), nil
+}
@@ -0,0 +1,45 @@
+package session
+
+import "time"
+
+// Step identifies where a Session is within a multi-turn command flow.
+type Step string
+
+const (
+	// StepConfirm indicates the Session is waiting for the user to confirm
+	// or cancel a previously requested action
+	StepConfirm Step = "confirm"
+
+	// StepWizard indicates the Session is partway through a multi-step
+	// wizard, e.g. choosing a namespace, then a resource, then an action
+	StepWizard Step = "wizard"
+)
+
+// Session holds the in-progress state of a personal IM (aka "im")
+// conversation with a single Slack user, keyed by the im's channel ID.
+type Session struct {
+	// ChannelID is the Slack im channel this Session belongs to
+	ChannelID string
+
+	// UserID is the Slack user on the other end of the im
+	UserID string
+
+	// Step says what kind of flow the Session is currently in the middle of
+	Step Step
+
+	// Cmd is the name of the command the flow will eventually run
+	Cmd string
+
+	// Data holds arbitrary state accumulated across turns, e.g. wizard
+	// answers collected so far or the augments of the command awaiting
+	// confirmation
+	Data map[string]string
+
+	// ExpiresAt is when the Session should be considered stale and dropped
+	ExpiresAt time.Time
+}
+
+// Expired returns true if the Session's ExpiresAt has passed.
+func (s *Session) Expired() bool {
+	return time.Now().After(s.ExpiresAt)
+}
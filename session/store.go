@@ -0,0 +1,23 @@
+package session
+
+// Store persists Sessions between messages in a personal IM conversation.
+// Implementations may keep Sessions in memory or hand them off to an
+// external system like Redis so multiple bot replicas can share state.
+type Store interface {
+	// Get retrieves the Session for channelID. The second return value is
+	// false if no Session exists, or exists but has expired.
+	Get(channelID string) (*Session, bool, error)
+
+	// Set creates or replaces the Session for its ChannelID.
+	Set(sess *Session) error
+
+	// Delete removes any Session stored for channelID. It is not an error
+	// if none exists.
+	Delete(channelID string) error
+
+	// Count returns the number of Sessions currently live, i.e. neither
+	// Deleted nor expired. Used to report an accurate count of in-progress
+	// conversations without callers having to track Set/Delete calls
+	// themselves.
+	Count() (int, error)
+}